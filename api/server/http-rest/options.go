@@ -0,0 +1,79 @@
+package httprest
+
+import (
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option for configuring the http-rest server.
+type Option func(s *Server) error
+
+// WithRouter sets the router used to serve requests.
+func WithRouter(router *mux.Router) Option {
+	return func(s *Server) error {
+		s.cfg.router = router
+		return nil
+	}
+}
+
+// WithHTTPAddr sets the listen address for the server.
+func WithHTTPAddr(addr string) Option {
+	return func(s *Server) error {
+		s.cfg.httpAddr = addr
+		return nil
+	}
+}
+
+// WithAllowedOrigins sets the allowed CORS origins for the server.
+func WithAllowedOrigins(origins []string) Option {
+	return func(s *Server) error {
+		s.cfg.allowedOrigins = origins
+		return nil
+	}
+}
+
+// WithTimeout sets the read-header timeout applied to every request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *Server) error {
+		s.cfg.timeout = timeout
+		return nil
+	}
+}
+
+// WithReadTimeout sets the maximum duration for reading an entire request, including the
+// body, before the connection is closed.
+func WithReadTimeout(timeout time.Duration) Option {
+	return func(s *Server) error {
+		s.cfg.readTimeout = timeout
+		return nil
+	}
+}
+
+// WithWriteTimeout sets the maximum duration before timing out writes of the response.
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(s *Server) error {
+		s.cfg.writeTimeout = timeout
+		return nil
+	}
+}
+
+// WithIdleTimeout sets the maximum amount of time to wait for the next request when
+// keep-alives are enabled.
+func WithIdleTimeout(timeout time.Duration) Option {
+	return func(s *Server) error {
+		s.cfg.idleTimeout = timeout
+		return nil
+	}
+}
+
+// WithTracing wraps every request in an OpenTelemetry span created from tp, and adds a
+// traceresponse header to every response so clients can correlate their request to the
+// server-side span.
+func WithTracing(tp trace.TracerProvider) Option {
+	return func(s *Server) error {
+		s.cfg.tracer = traceConfig{provider: tp, enabled: true}
+		return nil
+	}
+}
@@ -2,7 +2,9 @@ package httprest
 
 import (
 	"context"
+	"net"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -26,22 +28,30 @@ type config struct {
 	handler        httpHandler
 	router         *mux.Router
 	timeout        time.Duration
+	readTimeout    time.Duration
+	writeTimeout   time.Duration
+	idleTimeout    time.Duration
+	tracer         traceConfig
 }
 
 // Server serves HTTP traffic.
 type Server struct {
 	cfg          *config
 	server       *http.Server
+	listener     net.Listener
 	cancel       context.CancelFunc
 	ctx          context.Context
 	startFailure error
+	ready        chan struct{}
+	isReady      atomic.Bool
 }
 
 // New returns a new instance of the Server.
 func New(ctx context.Context, opts ...Option) (*Server, error) {
 	g := &Server{
-		ctx: ctx,
-		cfg: &config{},
+		ctx:   ctx,
+		cfg:   &config{},
+		ready: make(chan struct{}),
 	}
 	for _, opt := range opts {
 		if err := opt(g); err != nil {
@@ -53,23 +63,50 @@ func New(ctx context.Context, opts ...Option) (*Server, error) {
 		return nil, errors.New("router option not configured")
 	}
 
-	// TODO: actually use the timeout config provided
+	g.cfg.router.HandleFunc("/healthz", g.handleHealthz)
+	g.cfg.router.HandleFunc("/readyz", g.handleReadyz)
+
+	handler := withTracing(g.cfg.router, g.cfg.tracer)
+
 	g.server = &http.Server{
-		Addr:              g.cfg.httpAddr,
-		Handler:           g.cfg.router,
-		ReadHeaderTimeout: time.Second,
+		Handler:           handler,
+		ReadHeaderTimeout: firstNonZero(g.cfg.timeout, time.Second),
+		ReadTimeout:       g.cfg.readTimeout,
+		WriteTimeout:      g.cfg.writeTimeout,
+		IdleTimeout:       g.cfg.idleTimeout,
 	}
 	return g, nil
 }
 
-// Start the http rest service.
+// firstNonZero returns d if it is set, or fallback otherwise.
+func firstNonZero(d, fallback time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return fallback
+}
+
+// Start the http rest service. The listener is bound synchronously so that a bad address
+// or a port already in use is surfaced to the caller before Start returns, rather than
+// racing Status() from a background goroutine.
 func (g *Server) Start() {
 	_, cancel := context.WithCancel(g.ctx)
 	g.cancel = cancel
 
+	listener, err := net.Listen("tcp", g.cfg.httpAddr)
+	if err != nil {
+		log.WithError(err).Error("Failed to bind HTTP server listener")
+		g.startFailure = err
+		close(g.ready)
+		return
+	}
+	g.listener = listener
+	g.isReady.Store(true)
+	close(g.ready)
+
 	go func() {
 		log.WithField("address", g.cfg.httpAddr).Info("Starting HTTP server")
-		if err := g.server.ListenAndServe(); err != http.ErrServerClosed {
+		if err := g.server.Serve(listener); err != nil && err != http.ErrServerClosed {
 			log.WithError(err).Error("Failed to start HTTP server")
 			g.startFailure = err
 			return
@@ -77,6 +114,29 @@ func (g *Server) Start() {
 	}()
 }
 
+// Ready returns a channel that closes once the server's listener is bound and accepting
+// connections, or once binding has failed — check Status after the channel closes to
+// tell the two apart, so a caller blocked on <-Ready() to sequence startup can't hang
+// forever on a bad address or a port already in use.
+func (g *Server) Ready() <-chan struct{} {
+	return g.ready
+}
+
+// handleHealthz reports liveness: the process is up and able to handle requests at all.
+func (g *Server) handleHealthz(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleReadyz reports readiness: the listener has been bound via Start and the server is
+// actually accepting connections, not just that the process is alive.
+func (g *Server) handleReadyz(w http.ResponseWriter, _ *http.Request) {
+	if !g.isReady.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
 // Status of the HTTP server. Returns an error if this service is unhealthy.
 func (g *Server) Status() error {
 	if g.startFailure != nil {
@@ -102,4 +162,4 @@ func (g *Server) Stop() error {
 		g.cancel()
 	}
 	return nil
-}
\ No newline at end of file
+}
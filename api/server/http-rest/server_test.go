@@ -0,0 +1,50 @@
+package httprest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func waitReady(t *testing.T, s *Server) {
+	t.Helper()
+	select {
+	case <-s.Ready():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Ready() never closed")
+	}
+}
+
+func TestServer_ReadyClosesOnBindFailure(t *testing.T) {
+	s, err := New(context.Background(), WithRouter(mux.NewRouter()), WithHTTPAddr("bad:address:format"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go s.Start()
+	waitReady(t, s)
+
+	if err := s.Status(); err == nil {
+		t.Fatal("expected Status() to report the listener bind failure, so a caller blocked on <-Ready() can " +
+			"distinguish success from failure instead of hanging forever")
+	}
+}
+
+func TestServer_ReadyClosesOnSuccessfulBind(t *testing.T) {
+	s, err := New(context.Background(), WithRouter(mux.NewRouter()), WithHTTPAddr("127.0.0.1:0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go s.Start()
+	waitReady(t, s)
+
+	if err := s.Status(); err != nil {
+		t.Fatalf("Status() = %v, want nil after a successful bind", err)
+	}
+	if err := s.Stop(); err != nil {
+		t.Fatalf("Stop() = %v, want nil", err)
+	}
+}
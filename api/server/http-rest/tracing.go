@@ -0,0 +1,68 @@
+package httprest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceConfig holds the OTEL wiring for the server, grouped into a single config field so
+// a nil TracerProvider never needs special-casing beyond the one enabled check below.
+type traceConfig struct {
+	provider trace.TracerProvider
+	enabled  bool
+}
+
+// traceresponseHeader is the W3C Trace Context response header, the response-side
+// counterpart to the "traceparent" request header, letting a client correlate its
+// request with the server-side span even when the client itself isn't instrumented.
+const traceresponseHeader = "traceresponse"
+
+// withTracing wraps router with OTEL HTTP instrumentation and a traceresponse header
+// when tc is enabled, and otherwise returns router unchanged.
+func withTracing(router *mux.Router, tc traceConfig) http.Handler {
+	if !tc.enabled {
+		return router
+	}
+
+	router.Use(routeTemplateMiddleware)
+
+	// traceresponseMiddleware wraps the router rather than going through router.Use, since
+	// mux only runs Use middleware on matched routes and 404/405 responses need it too.
+	return otelhttp.NewHandler(traceresponseMiddleware(router), "http.server",
+		otelhttp.WithTracerProvider(tc.provider),
+		otelhttp.WithPropagators(otel.GetTextMapPropagator()),
+	)
+}
+
+// routeTemplateMiddleware attaches the matched mux route template (e.g.
+// "/eth/v1/beacon/blocks/{block_id}") to the current span, so traces group by route
+// rather than by every distinct path parameter value.
+func routeTemplateMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		span := trace.SpanFromContext(r.Context())
+		if route := mux.CurrentRoute(r); route != nil {
+			if tmpl, err := route.GetPathTemplate(); err == nil {
+				span.SetName(tmpl)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// traceresponseMiddleware writes the current span's context to the response as a
+// "traceresponse" header, following the same wire format as the "traceparent" request
+// header: version-traceID-spanID-flags.
+func traceresponseMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sc := trace.SpanContextFromContext(r.Context())
+		if sc.IsValid() {
+			w.Header().Set(traceresponseHeader, fmt.Sprintf("00-%s-%s-%02x", sc.TraceID(), sc.SpanID(), byte(sc.TraceFlags())))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
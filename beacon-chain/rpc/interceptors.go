@@ -0,0 +1,16 @@
+package rpc
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+)
+
+// TracingServerOption returns the grpc.ServerOption that extracts the W3C trace context
+// an upstream beacon node or MEV builder attaches to its request, so spans like
+// ProposerServer.GetLocalHeader continue that caller's trace instead of starting a new
+// root every time a request crosses a process boundary. Append it to whatever other
+// grpc.ServerOptions (TLS, interceptors, ...) the beacon node's real grpc.NewServer call
+// already passes when constructing the server that serves this package's *.Server types.
+func TracingServerOption() grpc.ServerOption {
+	return grpc.StatsHandler(otelgrpc.NewServerHandler())
+}
@@ -0,0 +1,17 @@
+package rpc
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func TestTracingServerOption(t *testing.T) {
+	opt := TracingServerOption()
+	if opt == nil {
+		t.Fatal("TracingServerOption returned a nil grpc.ServerOption")
+	}
+	// grpc.NewServer panics if an option is malformed, so successfully applying it here
+	// is what actually exercises otelgrpc.NewServerHandler's wiring.
+	_ = grpc.NewServer(opt)
+}
@@ -2,51 +2,81 @@ package validator
 
 import (
 	"context"
+	"encoding/hex"
 
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/encoding/ssz"
 	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
-	"go.opencensus.io/trace"
-	"google.golang.org/grpc/codes"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	grpccodes "google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 // GetLocalHeader returns the local header for a given slot and proposer index.
 func (vs *Server) GetLocalHeader(ctx context.Context, req *ethpb.HeaderRequest) (*enginev1.ExecutionPayloadHeaderEPBS, error) {
-	ctx, span := trace.StartSpan(ctx, "ProposerServer.GetLocalHeader")
+	ctx, span := otel.Tracer("").Start(ctx, "ProposerServer.GetLocalHeader")
 	defer span.End()
 
+	slot := req.Slot
+	epoch := slots.ToEpoch(slot)
+	span.SetAttributes(
+		attribute.Int64("slot", int64(slot)),
+		attribute.Int64("epoch", int64(epoch)),
+		attribute.Int64("proposer_index", int64(req.ProposerIndex)),
+	)
+
 	if vs.SyncChecker.Syncing() {
-		return nil, status.Error(codes.FailedPrecondition, "Syncing to latest head, not ready to respond")
+		err := status.Error(grpccodes.FailedPrecondition, "Syncing to latest head, not ready to respond")
+		recordErr(span, err)
+		return nil, err
 	}
 
 	if err := vs.optimisticStatus(ctx); err != nil {
-		return nil, status.Errorf(codes.FailedPrecondition, "Validator is not ready to propose: %v", err)
+		wrapped := status.Errorf(grpccodes.FailedPrecondition, "Validator is not ready to propose: %v", err)
+		recordErr(span, wrapped)
+		return nil, wrapped
 	}
 
-	slot := req.Slot
-	epoch := slots.ToEpoch(slot)
 	if params.BeaconConfig().EPBSForkEpoch > epoch {
-		return nil, status.Errorf(codes.FailedPrecondition, "EPBS fork has not occurred yet")
+		err := status.Errorf(grpccodes.FailedPrecondition, "EPBS fork has not occurred yet")
+		recordErr(span, err)
+		return nil, err
 	}
 
 	st, parentRoot, err := vs.getParentState(ctx, slot)
 	if err != nil {
+		recordErr(span, err)
 		return nil, err
 	}
+	span.SetAttributes(attribute.String("parent_root", "0x"+hex.EncodeToString(parentRoot[:])))
 
 	proposerIndex := req.ProposerIndex
-	localPayload, err := vs.getLocalPayloadFromEngine(ctx, st, parentRoot, slot, proposerIndex)
+	engineCtx, engineSpan := otel.Tracer("").Start(ctx, "ProposerServer.getLocalPayloadFromEngine")
+	localPayload, err := vs.getLocalPayloadFromEngine(engineCtx, st, parentRoot, slot, proposerIndex)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get local payload: %v", err)
+		recordErr(engineSpan, err)
+		engineSpan.End()
+		wrapped := status.Errorf(grpccodes.Internal, "Could not get local payload: %v", err)
+		recordErr(span, wrapped)
+		return nil, wrapped
 	}
+	engineSpan.End()
 
+	_, kzgSpan := otel.Tracer("").Start(ctx, "ssz.KzgCommitmentsRoot")
 	kzgRoot, err := ssz.KzgCommitmentsRoot(localPayload.BlobsBundle.KzgCommitments)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get kzg commitments root: %v", err)
+		recordErr(kzgSpan, err)
+		kzgSpan.End()
+		wrapped := status.Errorf(grpccodes.Internal, "Could not get kzg commitments root: %v", err)
+		recordErr(span, wrapped)
+		return nil, wrapped
 	}
+	kzgSpan.End()
 
 	return &enginev1.ExecutionPayloadHeaderEPBS{
 		ParentBlockHash:        localPayload.ExecutionData.ParentHash(),
@@ -59,3 +89,10 @@ func (vs *Server) GetLocalHeader(ctx context.Context, req *ethpb.HeaderRequest)
 		BlobKzgCommitmentsRoot: kzgRoot[:],
 	}, nil
 }
+
+// recordErr records err on span and marks it as errored, matching the OTEL convention of
+// calling RecordError alongside setting an error status.
+func recordErr(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v2"
+)
+
+// SamplerConfigFileFlag points to a YAML file describing a SamplerConfig (see its yaml
+// tags), letting operators tune AlwaysSample and MaxSpansPerSecond per deployment without
+// recompiling.
+var SamplerConfigFileFlag = &cli.StringFlag{
+	Name:  "tracing-sampler-config",
+	Usage: "Path to a YAML file configuring the span sampler stack (sample_fraction, always_sample, max_spans_per_second)",
+}
+
+// Flags are the CLI flags this package defines. A command that exposes tracing
+// configuration should append these to its own flag list, the same way it already does
+// for other subpackages' flags, so SamplerConfigFileFlag actually reaches cli.Context.
+var Flags = []cli.Flag{
+	SamplerConfigFileFlag,
+}
+
+// SamplerConfigFromContext builds a SamplerConfig from CLI flags: when
+// --tracing-sampler-config is set, the full config is loaded from that file; otherwise
+// sampleFraction alone is used, with AlwaysSample and MaxSpansPerSecond left at their zero
+// values, matching the behavior before this flag existed.
+func SamplerConfigFromContext(c *cli.Context, sampleFraction float64) (SamplerConfig, error) {
+	path := c.String(SamplerConfigFileFlag.Name)
+	if path == "" {
+		return SamplerConfig{SampleFraction: sampleFraction}, nil
+	}
+	return LoadSamplerConfig(path)
+}
+
+// LoadSamplerConfig reads and parses a SamplerConfig from the YAML file at path.
+func LoadSamplerConfig(path string) (SamplerConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return SamplerConfig{}, errors.Wrap(err, "could not read sampler config file")
+	}
+	var cfg SamplerConfig
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return SamplerConfig{}, errors.Wrap(err, "could not parse sampler config file")
+	}
+	return cfg, nil
+}
+
+// SetupFromCLI is SetupWithSampler for a command that registered Flags: it resolves the
+// sampler stack from --tracing-sampler-config (falling back to the bare sampleFraction
+// when the flag is unset) and then sets up tracing exactly as SetupWithSampler does.
+func SetupFromCLI(c *cli.Context, serviceName, processName, endpoint string, sampleFraction float64, enable bool) error {
+	samplerCfg, err := SamplerConfigFromContext(c, sampleFraction)
+	if err != nil {
+		return err
+	}
+	return SetupWithSampler(serviceName, processName, endpoint, samplerCfg, enable)
+}
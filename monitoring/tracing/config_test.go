@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+func TestLoadSamplerConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sampler.yaml")
+	contents := "sample_fraction: 0.25\nalways_sample:\n  - \"ProposerServer.*\"\nmax_spans_per_second: 50\n"
+	if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadSamplerConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.SampleFraction != 0.25 {
+		t.Errorf("SampleFraction = %v, want 0.25", cfg.SampleFraction)
+	}
+	if len(cfg.AlwaysSample) != 1 || cfg.AlwaysSample[0] != "ProposerServer.*" {
+		t.Errorf("AlwaysSample = %v, want [ProposerServer.*]", cfg.AlwaysSample)
+	}
+	if cfg.MaxSpansPerSecond != 50 {
+		t.Errorf("MaxSpansPerSecond = %v, want 50", cfg.MaxSpansPerSecond)
+	}
+}
+
+func TestLoadSamplerConfig_MissingFile(t *testing.T) {
+	if _, err := LoadSamplerConfig(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatal("expected an error reading a missing sampler config file")
+	}
+}
+
+func TestSamplerConfigFromContext_NoFlag(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.String(SamplerConfigFileFlag.Name, "", "")
+	c := cli.NewContext(cli.NewApp(), set, nil)
+
+	cfg, err := SamplerConfigFromContext(c, 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.SampleFraction != 0.1 {
+		t.Errorf("SampleFraction = %v, want 0.1", cfg.SampleFraction)
+	}
+	if len(cfg.AlwaysSample) != 0 || cfg.MaxSpansPerSecond != 0 {
+		t.Errorf("expected AlwaysSample/MaxSpansPerSecond to stay zero without the flag, got %+v", cfg)
+	}
+}
+
+func TestSamplerConfigFromContext_WithFlag(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sampler.yaml")
+	if err := os.WriteFile(path, []byte("sample_fraction: 0.5\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.String(SamplerConfigFileFlag.Name, "", "")
+	c := cli.NewContext(cli.NewApp(), set, nil)
+	if err := set.Set(SamplerConfigFileFlag.Name, path); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := SamplerConfigFromContext(c, 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.SampleFraction != 0.5 {
+		t.Errorf("SampleFraction = %v, want 0.5 (from file, not the fallback)", cfg.SampleFraction)
+	}
+}
@@ -0,0 +1,211 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// defaultPrysmIngestSampleFraction is used when TracerOpts.PrysmSampleFraction is left
+// at its zero value. It's intentionally small: the endpoint is meant to collect a
+// representative sample of network-wide behavior, not to replace an operator's own
+// tracing backend.
+const defaultPrysmIngestSampleFraction = 0.01
+
+// sensitiveAttributes are attribute keys whose values are replaced with "[redacted]" on
+// every span before it is exported to the public Prysm ingest endpoint.
+var sensitiveAttributes = map[attribute.Key]bool{
+	"validator.pubkey": true,
+	"peer.address":     true,
+	"net.peer.ip":      true,
+}
+
+// TracerOpts configures where a process' spans are exported to. Default controls export
+// to the operator's own backend (the defaultEndpoint passed to NewTracerProvider); Prysm controls an
+// additional, redacted export to a Prysm-hosted collector for network-wide observability.
+type TracerOpts struct {
+	Default bool
+	Prysm   bool
+
+	// PrysmEndpoint is the OTLP HTTP endpoint of the Prysm-hosted collector.
+	PrysmEndpoint string
+	// PrysmAuthToken is sent as a bearer token on every export request to PrysmEndpoint.
+	PrysmAuthToken string
+	// PrysmSampleFraction overrides defaultPrysmIngestSampleFraction, the portion of
+	// spans additionally forwarded to PrysmEndpoint on top of the root sampling decision.
+	// Zero uses the default.
+	PrysmSampleFraction float64
+}
+
+// NewTracerProvider builds a TracerProvider that fans spans out to every destination
+// enabled in opts. Every destination shares one resource (service.name, process_name,
+// prysm.version and network); the Default destination receives every sampled span, while
+// the Prysm destination keeps only a PrysmSampleFraction slice of them. samplerCfg drives
+// the root sampling decision via NewSampler, the same composed allowlist/rate-limit
+// stack SetupWithSampler installs, so a process doesn't lose those guarantees just
+// because it also runs in public-ingest mode. Use this instead of Setup when a process
+// needs to export to more than one backend at once.
+func NewTracerProvider(ctx context.Context, serviceName, processName, network, defaultEndpoint string, samplerCfg SamplerConfig, opts TracerOpts) (*sdktrace.TracerProvider, error) {
+	if serviceName == "" {
+		return nil, errors.New("tracing service name cannot be empty")
+	}
+
+	tpOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resourceForNetwork(serviceName, processName, network)),
+	}
+
+	if opts.Default {
+		exporter, err := newOTLPExporter(ctx, defaultEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		tpOpts = append(tpOpts,
+			sdktrace.WithBatcher(exporter, sdktrace.WithMaxQueueSize(maxQueueSize), sdktrace.WithBatchTimeout(batchTimeout)),
+		)
+	}
+
+	if opts.Prysm {
+		exporter, err := otlptracehttp.New(ctx,
+			otlptracehttp.WithEndpoint(opts.PrysmEndpoint),
+			otlptracehttp.WithHeaders(map[string]string{"Authorization": "Bearer " + opts.PrysmAuthToken}),
+		)
+		if err != nil {
+			return nil, err
+		}
+		// Redaction has to happen at the exporter, not a SpanProcessor: OnStart runs
+		// before the handler sets real attributes (so a placeholder written there just
+		// gets overwritten later), and OnEnd's ReadOnlySpan has no attribute setter at
+		// all. Wrapping ExportSpans lets us hand the real otlptracehttp exporter a
+		// redacted copy of each span right before it goes over the wire.
+		redacted := newRedactingExporter(exporter)
+		bsp := sdktrace.NewBatchSpanProcessor(redacted, sdktrace.WithMaxQueueSize(maxQueueSize), sdktrace.WithBatchTimeout(batchTimeout))
+		ratio := opts.PrysmSampleFraction
+		if ratio == 0 {
+			ratio = defaultPrysmIngestSampleFraction
+		}
+		tpOpts = append(tpOpts,
+			sdktrace.WithSpanProcessor(newSampledProcessor(bsp, ratio)),
+		)
+	}
+
+	// The overall sampling decision is still made once at the root; each processor above
+	// just decides where a sampled span is sent, not whether it's sampled at all.
+	tpOpts = append(tpOpts, sdktrace.WithSampler(NewSampler(samplerCfg)))
+
+	return sdktrace.NewTracerProvider(tpOpts...), nil
+}
+
+// resourceForNetwork extends resourceFor with the network attribute, which the public
+// ingest endpoint uses to distinguish mainnet from testnet traffic.
+func resourceForNetwork(serviceName, processName, network string) *resource.Resource {
+	return resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceNameKey.String(serviceName),
+		attribute.String("process_name", processName),
+		attribute.String("prysm.version", version.Version()),
+		attribute.String("network", network),
+	)
+}
+
+// redactingExporter wraps a SpanExporter and replaces sensitiveAttributes with a
+// placeholder value on every span before handing it to next, so validator pubkeys and
+// remote IPs never leave the host bound for the public ingest endpoint.
+type redactingExporter struct {
+	next sdktrace.SpanExporter
+}
+
+func newRedactingExporter(next sdktrace.SpanExporter) sdktrace.SpanExporter {
+	return &redactingExporter{next: next}
+}
+
+// ExportSpans implements sdktrace.SpanExporter.
+func (e *redactingExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	redacted := make([]sdktrace.ReadOnlySpan, len(spans))
+	for i, s := range spans {
+		redacted[i] = redactedSpan{s}
+	}
+	return e.next.ExportSpans(ctx, redacted)
+}
+
+// Shutdown implements sdktrace.SpanExporter.
+func (e *redactingExporter) Shutdown(ctx context.Context) error {
+	return e.next.Shutdown(ctx)
+}
+
+// redactedSpan overrides Attributes on a ReadOnlySpan to redact sensitiveAttributes,
+// leaving every other accessor (name, timing, status, resource, ...) untouched.
+type redactedSpan struct {
+	sdktrace.ReadOnlySpan
+}
+
+// Attributes implements sdktrace.ReadOnlySpan.
+func (r redactedSpan) Attributes() []attribute.KeyValue {
+	orig := r.ReadOnlySpan.Attributes()
+	out := make([]attribute.KeyValue, len(orig))
+	for i, kv := range orig {
+		if sensitiveAttributes[kv.Key] {
+			out[i] = attribute.String(string(kv.Key), "[redacted]")
+			continue
+		}
+		out[i] = kv
+	}
+	return out
+}
+
+// sampledProcessor applies an additional, destination-local sampling ratio on top of the
+// TracerProvider's root sampler, so a low-traffic destination like the public Prysm
+// collector can see a smaller slice of spans than the operator's own backend without
+// affecting whether those spans are recorded at all.
+type sampledProcessor struct {
+	next  sdktrace.SpanProcessor
+	ratio float64
+}
+
+func newSampledProcessor(next sdktrace.SpanProcessor, ratio float64) sdktrace.SpanProcessor {
+	return &sampledProcessor{next: next, ratio: ratio}
+}
+
+// keep reports whether a span's trace ID falls within this processor's sampling ratio,
+// using the same low-bits-of-trace-ID technique as sdktrace.TraceIDRatioBased.
+func (s *sampledProcessor) keep(traceID oteltrace.TraceID) bool {
+	if s.ratio >= 1 {
+		return true
+	}
+	upperBound := uint64(s.ratio * (1 << 63))
+	var x uint64
+	for _, b := range traceID[8:] {
+		x = x<<8 | uint64(b)
+	}
+	return x>>1 < upperBound
+}
+
+// OnStart implements sdktrace.SpanProcessor.
+func (s *sampledProcessor) OnStart(ctx context.Context, span sdktrace.ReadWriteSpan) {
+	if s.keep(span.SpanContext().TraceID()) {
+		s.next.OnStart(ctx, span)
+	}
+}
+
+// OnEnd implements sdktrace.SpanProcessor.
+func (s *sampledProcessor) OnEnd(span sdktrace.ReadOnlySpan) {
+	if s.keep(span.SpanContext().TraceID()) {
+		s.next.OnEnd(span)
+	}
+}
+
+// Shutdown implements sdktrace.SpanProcessor.
+func (s *sampledProcessor) Shutdown(ctx context.Context) error {
+	return s.next.Shutdown(ctx)
+}
+
+// ForceFlush implements sdktrace.SpanProcessor.
+func (s *sampledProcessor) ForceFlush(ctx context.Context) error {
+	return s.next.ForceFlush(ctx)
+}
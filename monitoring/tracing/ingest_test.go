@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func endedTestSpan(t *testing.T, attrs ...attribute.KeyValue) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSpanProcessor(recorder),
+	)
+	_, span := tp.Tracer("test").Start(context.Background(), "test-span")
+	span.SetAttributes(attrs...)
+	span.End()
+
+	ended := recorder.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("expected exactly one ended span, got %d", len(ended))
+	}
+	return ended[0]
+}
+
+func TestRedactedSpan_StripsSensitiveAttributesOnly(t *testing.T) {
+	span := endedTestSpan(t,
+		attribute.String("validator.pubkey", "0xdeadbeef"),
+		attribute.String("net.peer.ip", "10.0.0.1"),
+		attribute.Int64("slot", 42),
+	)
+
+	redacted := redactedSpan{span}.Attributes()
+
+	got := map[string]string{}
+	for _, kv := range redacted {
+		got[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	if got["validator.pubkey"] != "[redacted]" {
+		t.Errorf("validator.pubkey = %q, want [redacted]", got["validator.pubkey"])
+	}
+	if got["net.peer.ip"] != "[redacted]" {
+		t.Errorf("net.peer.ip = %q, want [redacted]", got["net.peer.ip"])
+	}
+	if got["slot"] != "42" {
+		t.Errorf("slot = %q, want 42 (non-sensitive attributes must pass through untouched)", got["slot"])
+	}
+}
+
+func TestRedactedSpan_NonSensitiveSpanUnchanged(t *testing.T) {
+	span := endedTestSpan(t, attribute.Int64("epoch", 7))
+
+	redacted := redactedSpan{span}.Attributes()
+	if len(redacted) != 1 || redacted[0].Value.Emit() != "7" {
+		t.Errorf("expected the sole non-sensitive attribute to pass through unchanged, got %v", redacted)
+	}
+}
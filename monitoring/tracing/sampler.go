@@ -0,0 +1,133 @@
+package tracing
+
+import (
+	"path"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SamplerConfig tunes the sampler stack installed by NewSampler. A single sampleFraction
+// is too coarse once some spans (e.g. block-proposal flows) need to be traced exhaustively
+// while high-volume spans (e.g. gossip processing) only need a thin slice. The yaml tags
+// let operators tune this from --tracing-sampler-config instead of a recompile; see
+// LoadSamplerConfig.
+type SamplerConfig struct {
+	// SampleFraction is the base TraceIDRatioBased probability applied to any root span
+	// whose name isn't in AlwaysSample; such a span with a parent follows the parent's
+	// sampling decision instead.
+	SampleFraction float64 `yaml:"sample_fraction"`
+	// AlwaysSample lists path.Match-style glob patterns (e.g. "ProposerServer.*",
+	// "BlobSidecar.*") of span names that are always sampled, regardless of
+	// SampleFraction.
+	AlwaysSample []string `yaml:"always_sample"`
+	// MaxSpansPerSecond caps the total number of spans sampled in, across every span
+	// name, to protect the exporter's queue (see maxQueueSize) from being overwhelmed by
+	// a burst of otherwise-legitimately-sampled spans. Zero disables the cap.
+	MaxSpansPerSecond int `yaml:"max_spans_per_second"`
+}
+
+// NewSampler builds the composed sampler described by cfg: AlwaysSample is checked
+// unconditionally, ahead of the parent-based decision, so an allowlisted span name is
+// always sampled even when it arrives with a remote parent context that wasn't already
+// sampled (e.g. a request from a peer that didn't force-sample); anything else falls
+// through to a parent-based SampleFraction. The whole stack is wrapped in a rate limiter
+// when MaxSpansPerSecond is set. Compose this with sdktrace.WithSampler the same way a
+// bare TraceIDRatioBased sampler would be used.
+func NewSampler(cfg SamplerConfig) sdktrace.Sampler {
+	var sampler sdktrace.Sampler = allowlistSampler{
+		patterns: cfg.AlwaysSample,
+		fallback: sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleFraction)),
+	}
+
+	if cfg.MaxSpansPerSecond > 0 {
+		sampler = rateLimitedSampler{
+			next:    sampler,
+			limiter: newTokenBucket(cfg.MaxSpansPerSecond),
+		}
+	}
+
+	return sampler
+}
+
+// allowlistSampler forces a sampling decision for any span whose name matches one of
+// patterns — checked before fallback sees the span at all, so the override isn't limited
+// to root spans — and otherwise defers to fallback.
+type allowlistSampler struct {
+	patterns []string
+	fallback sdktrace.Sampler
+}
+
+// ShouldSample implements sdktrace.Sampler.
+func (a allowlistSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	for _, pattern := range a.patterns {
+		if ok, err := path.Match(pattern, p.Name); err == nil && ok {
+			return sdktrace.SamplingResult{
+				Decision:   sdktrace.RecordAndSample,
+				Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+			}
+		}
+	}
+	return a.fallback.ShouldSample(p)
+}
+
+// Description implements sdktrace.Sampler.
+func (a allowlistSampler) Description() string {
+	return "AllowlistSampler"
+}
+
+// rateLimitedSampler caps the number of spans next may sample per second, so a burst of
+// allowlisted or probabilistically-sampled spans can't overrun the exporter's queue.
+type rateLimitedSampler struct {
+	next    sdktrace.Sampler
+	limiter *tokenBucket
+}
+
+// ShouldSample implements sdktrace.Sampler.
+func (r rateLimitedSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	result := r.next.ShouldSample(p)
+	if result.Decision == sdktrace.Drop {
+		return result
+	}
+	if !r.limiter.Allow() {
+		result.Decision = sdktrace.Drop
+	}
+	return result
+}
+
+// Description implements sdktrace.Sampler.
+func (r rateLimitedSampler) Description() string {
+	return "RateLimitedSampler{" + r.next.Description() + "}"
+}
+
+// tokenBucket is a minimal per-second rate limiter: it refills to its full capacity once
+// per second rather than leaking continuously, which is simpler to reason about for a
+// "spans/sec" budget than a true leaky bucket.
+type tokenBucket struct {
+	mu        sync.Mutex
+	capacity  int
+	remaining int
+	window    time.Time
+}
+
+func newTokenBucket(perSecond int) *tokenBucket {
+	return &tokenBucket{capacity: perSecond, remaining: perSecond, window: time.Now()}
+}
+
+// Allow reports whether one more event fits in the current 1-second window.
+func (t *tokenBucket) Allow() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if now := time.Now(); now.Sub(t.window) >= time.Second {
+		t.remaining = t.capacity
+		t.window = now
+	}
+	if t.remaining <= 0 {
+		return false
+	}
+	t.remaining--
+	return true
+}
@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func remoteUnsampledParent(id byte) context.Context {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceIDWithByte(id),
+		SpanID:     trace.SpanID{1},
+		TraceFlags: 0, // unsampled, as if propagated from a peer that didn't force-sample
+		Remote:     true,
+	})
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+func traceIDWithByte(b byte) trace.TraceID {
+	var id trace.TraceID
+	id[len(id)-1] = b
+	return id
+}
+
+func TestNewSampler_AllowlistOverridesUnsampledRemoteParent(t *testing.T) {
+	sampler := NewSampler(SamplerConfig{SampleFraction: 0, AlwaysSample: []string{"ProposerServer.*"}})
+
+	result := sampler.ShouldSample(sdktrace.SamplingParameters{
+		ParentContext: remoteUnsampledParent(1),
+		TraceID:       traceIDWithByte(1),
+		Name:          "ProposerServer.GetLocalHeader",
+	})
+
+	if result.Decision != sdktrace.RecordAndSample {
+		t.Errorf("Decision = %v, want RecordAndSample: an allowlisted span name must be force-sampled even when it "+
+			"arrives with an unsampled remote parent, not just at the root", result.Decision)
+	}
+}
+
+func TestNewSampler_NonAllowlistedDefersToParentBased(t *testing.T) {
+	sampler := NewSampler(SamplerConfig{SampleFraction: 0, AlwaysSample: []string{"ProposerServer.*"}})
+
+	result := sampler.ShouldSample(sdktrace.SamplingParameters{
+		ParentContext: remoteUnsampledParent(2),
+		TraceID:       traceIDWithByte(2),
+		Name:          "Gossip.Process",
+	})
+
+	if result.Decision == sdktrace.RecordAndSample {
+		t.Error("a non-allowlisted span with SampleFraction 0 and an unsampled parent should not be force-sampled")
+	}
+}
+
+func TestTokenBucket_Allow(t *testing.T) {
+	tb := newTokenBucket(2)
+
+	if !tb.Allow() || !tb.Allow() {
+		t.Fatal("expected the first two events within capacity to be allowed")
+	}
+	if tb.Allow() {
+		t.Fatal("expected a third event in the same window to be denied")
+	}
+
+	tb.window = tb.window.Add(-time.Second - time.Millisecond)
+	if !tb.Allow() {
+		t.Fatal("expected the bucket to refill once the 1-second window rolls over")
+	}
+}
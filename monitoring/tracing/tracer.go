@@ -1,17 +1,22 @@
-// Package tracing sets up jaeger as an opentracing tool
+// Package tracing sets up an OpenTelemetry tracing pipeline, exported over OTLP,
 // for services in Prysm.
 package tracing
 
 import (
+	"context"
 	"errors"
+	"net/url"
+	"strings"
+	"time"
 
-	"contrib.go.opencensus.io/exporter/jaeger"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/sirupsen/logrus"
-	"go.opencensus.io/trace"
+	octrace "go.opencensus.io/trace"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
-	stdout "go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/bridge/opencensus"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -20,10 +25,30 @@ import (
 
 var log = logrus.WithField("prefix", "tracing")
 
-// Setup creates and initializes a new tracing configuration..
+// batchTimeout is the maximum delay before queued spans are flushed to the exporter,
+// mirroring the flush cadence the previous Jaeger exporter used by default.
+const batchTimeout = 5 * time.Second
+
+// maxQueueSize bounds how many spans may sit in the batch span processor's queue before
+// new spans are dropped, analogous to the old exporter's BufferMaxCount.
+const maxQueueSize = 10000
+
+// Setup creates and initializes a new OTLP-based tracing configuration, replacing the
+// retired OpenCensus/Jaeger pipeline. Existing go.opencensus.io/trace call sites keep
+// working during the migration because their spans are bridged into the OTEL
+// TracerProvider installed here, so nothing needs to move off trace.StartSpan at once.
 func Setup(serviceName, processName, endpoint string, sampleFraction float64, enable bool) error {
+	return SetupWithSampler(serviceName, processName, endpoint, SamplerConfig{SampleFraction: sampleFraction}, enable)
+}
+
+// SetupWithSampler is Setup with a composable sampler stack: samplerCfg.SampleFraction
+// still applies globally, but samplerCfg.AlwaysSample can force full sampling for
+// specific span names (e.g. "ProposerServer.*") and samplerCfg.MaxSpansPerSecond can cap
+// the rate at which spans are let through to protect the exporter's queue. See
+// SamplerConfig for how the pieces compose with TraceIDRatioBased.
+func SetupWithSampler(serviceName, processName, endpoint string, samplerCfg SamplerConfig, enable bool) error {
 	if !enable {
-		trace.ApplyConfig(trace.Config{DefaultSampler: trace.NeverSample()})
+		otel.SetTracerProvider(noopProvider())
 		return nil
 	}
 
@@ -31,61 +56,78 @@ func Setup(serviceName, processName, endpoint string, sampleFraction float64, en
 		return errors.New("tracing service name cannot be empty")
 	}
 
-	trace.ApplyConfig(trace.Config{
-		DefaultSampler:          trace.ProbabilitySampler(sampleFraction),
-		MaxMessageEventsPerSpan: 500,
-	})
-
-	log.Infof("Starting Jaeger exporter endpoint at address = %s", endpoint)
-	exporter, err := jaeger.NewExporter(jaeger.Options{
-		CollectorEndpoint: endpoint,
-		Process: jaeger.Process{
-			ServiceName: serviceName,
-			Tags: []jaeger.Tag{
-				jaeger.StringTag("process_name", processName),
-				jaeger.StringTag("version", version.Version()),
-			},
-		},
-		BufferMaxCount: 10000,
-		OnError: func(err error) {
-			log.WithError(err).Error("Could not process span")
-		},
-	})
+	exporter, err := newOTLPExporter(context.Background(), endpoint)
 	if err != nil {
 		return err
 	}
-	trace.RegisterExporter(exporter)
 
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter, sdktrace.WithMaxQueueSize(maxQueueSize), sdktrace.WithBatchTimeout(batchTimeout)),
+		sdktrace.WithResource(resourceFor(serviceName, processName)),
+		sdktrace.WithSampler(NewSampler(samplerCfg)),
+	)
+	installGlobals(tp)
+
+	// Bridge any remaining go.opencensus.io/trace.StartSpan call sites into the OTEL
+	// tracer so operators can flip to OTLP without double-instrumenting every caller.
+	octrace.DefaultTracer = opencensus.NewTracer(tp.Tracer(serviceName))
+
+	log.WithField("endpoint", endpoint).Info("Starting OTLP exporter")
 	return nil
 }
 
-// SetupOtel creates and initializes a new tracing configuration using OpenTelemetry..
+// SetupOtel is a deprecated alias kept for existing call sites; Setup now speaks OTLP
+// directly instead of writing to stdout.
+//
+// Deprecated: use Setup instead.
 func SetupOtel(serviceName, processName, endpoint string, sampleFraction float64, enable bool) error {
-	if !enable {
-		// If tracing is disabled, return immediately
-		return nil
-	}
+	return Setup(serviceName, processName, endpoint, sampleFraction, enable)
+}
 
-	if serviceName == "" {
-		return errors.New("tracing service name cannot be empty")
-	}
+// installGlobals registers the provider and the W3C TraceContext + Baggage propagators as
+// the process-wide defaults so incoming and outgoing requests carry trace context.
+func installGlobals(tp *sdktrace.TracerProvider) {
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
 
-	exporter, err := stdout.New(stdout.WithPrettyPrint())
-	if err != nil {
-		return err
-	}
+// noopProvider returns a TracerProvider that never samples, used when tracing is disabled.
+func noopProvider() *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.NeverSample()))
+}
 
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
-		sdktrace.WithResource(resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNameKey.String(serviceName),
-			attribute.String("process_name", processName),
-		)),
-		sdktrace.WithSampler(sdktrace.TraceIDRatioBased(sampleFraction)),
+// resourceFor builds the OTEL resource attributes shared by every exporter destination.
+func resourceFor(serviceName, processName string) *resource.Resource {
+	return resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceNameKey.String(serviceName),
+		attribute.String("process_name", processName),
+		attribute.String("prysm.version", version.Version()),
 	)
-	otel.SetTracerProvider(tp)
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
-	log.Printf("Tracing enabled with endpoint: %s", endpoint)
-	return nil
+}
+
+// newOTLPExporter builds an OTLP span exporter, auto-detecting whether endpoint should be
+// dialed over gRPC or plain HTTP from its URL scheme. An endpoint with no scheme (e.g.
+// "localhost:4317") is assumed to be gRPC, matching the OTLP collector's default port.
+func newOTLPExporter(ctx context.Context, endpoint string) (sdktrace.SpanExporter, error) {
+	scheme, host := splitEndpoint(endpoint)
+	switch scheme {
+	case "http", "https":
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(host)}
+		if scheme == "http" {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	default:
+		return otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(host), otlptracegrpc.WithInsecure())
+	}
+}
+
+// splitEndpoint separates a configured endpoint into its URL scheme (empty if none was
+// given) and the host[:port] portion the exporter clients expect.
+func splitEndpoint(endpoint string) (scheme, host string) {
+	if u, err := url.Parse(endpoint); err == nil && u.Scheme != "" && u.Host != "" {
+		return u.Scheme, u.Host
+	}
+	return "", strings.TrimPrefix(strings.TrimPrefix(endpoint, "http://"), "https://")
 }